@@ -83,3 +83,48 @@ func TestEngineUtil(t *testing.T) {
 	require.False(t, lockIter.Valid())
 	lockIter.Close()
 }
+
+func TestDeleteRange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine_util")
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	db, err := badger.Open(opts)
+	require.Nil(t, err)
+	defer db.Close()
+
+	batch := new(WriteBatch)
+	batch.SetCF(CF_DEFAULT, []byte("a"), []byte("a1"))
+	batch.SetCF(CF_DEFAULT, []byte("b"), []byte("b1"))
+	batch.SetCF(CF_DEFAULT, []byte("c"), []byte("c1"))
+	batch.SetCF(CF_WRITE, []byte("a"), []byte("a2"))
+	batch.SetCF(CF_WRITE, []byte("c"), []byte("c2"))
+	batch.SetCF(CF_LOCK, []byte("b"), []byte("b3"))
+	batch.SetCF(CF_LOCK, []byte("d"), []byte("d3"))
+	err = batch.WriteToDB(db)
+	require.Nil(t, err)
+
+	count, err := DeleteRange(db, []byte("a"), []byte("c"))
+	require.Nil(t, err)
+	require.Equal(t, 4, count)
+
+	_, err = GetCF(db, CF_DEFAULT, []byte("a"))
+	require.Equal(t, err, badger.ErrKeyNotFound)
+	_, err = GetCF(db, CF_WRITE, []byte("a"))
+	require.Equal(t, err, badger.ErrKeyNotFound)
+	_, err = GetCF(db, CF_LOCK, []byte("b"))
+	require.Equal(t, err, badger.ErrKeyNotFound)
+	val, err := GetCF(db, CF_DEFAULT, []byte("c"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("c1"), val)
+	val, err = GetCF(db, CF_WRITE, []byte("c"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("c2"), val)
+	val, err = GetCF(db, CF_LOCK, []byte("d"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("d3"), val)
+
+	count, err = DeleteRange(db, []byte("a"), []byte("c"))
+	require.Nil(t, err)
+	require.Equal(t, 0, count)
+}
@@ -27,7 +27,8 @@ func GetCFFromTxn(txn *badger.Txn, cf string, key []byte) ([]byte, error) {
 	return val, err
 }
 
-func DeleteRange(db *badger.DB, startKey, endKey []byte) error {
+// DeleteRange deletes all keys in [startKey, endKey) of every CF, and returns the number of keys deleted.
+func DeleteRange(db *badger.DB, startKey, endKey []byte) (int, error) {
 	batch := new(WriteBatch)
 	txn := db.NewTransaction(false)
 	defer txn.Discard()
@@ -35,7 +36,10 @@ func DeleteRange(db *badger.DB, startKey, endKey []byte) error {
 		deleteRangeCF(txn, batch, cf, startKey, endKey)
 	}
 
-	return batch.WriteToDB(db)
+	if err := batch.WriteToDB(db); err != nil {
+		return 0, err
+	}
+	return batch.Len(), nil
 }
 
 func deleteRangeCF(txn *badger.Txn, batch *WriteBatch, cf string, startKey, endKey []byte) {
@@ -77,7 +77,7 @@ func (ris *RaftInnerServer) Write(ctx kvrpcpb.Context, batch []Modify) error {
 
 func (ris *RaftInnerServer) checkResponse(resp *raft_cmdpb.RaftCmdResponse, reqCount int) error {
 	if resp.Header.Error != nil {
-		return errors.Errorf(resp.Header.Error.String())
+		return &raftstore.RaftError{RequestErr: resp.Header.Error}
 	}
 	if len(resp.Responses) != reqCount {
 		return errors.Errorf("responses count %d is not equal to requests count %d",
@@ -105,7 +105,14 @@ func (ris *RaftInnerServer) Reader(ctx kvrpcpb.Context) (dbreader.DBReader, erro
 		return nil, err
 	}
 	cb.Wg.Wait()
+	return ris.snapReader(cb)
+}
 
+// snapReader builds a reader from a finished snap command, or returns the region error if there is one.
+func (ris *RaftInnerServer) snapReader(cb *message.Callback) (dbreader.DBReader, error) {
+	if err := ris.checkResponse(cb.Resp, 1); err != nil {
+		return nil, err
+	}
 	return dbreader.NewRegionReader(cb.RegionSnap.Txn, cb.RegionSnap.Region), nil
 }
 
@@ -0,0 +1,38 @@
+package inner_server
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/tikv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/tikv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResponseNotLeader(t *testing.T) {
+	ris := new(RaftInnerServer)
+	leader := &metapb.Peer{Id: 3, StoreId: 4}
+	resp := raftstore.ErrResp(&raftstore.ErrNotLeader{RegionId: 2, Leader: leader})
+
+	err := ris.checkResponse(resp, 1)
+	raftErr, ok := err.(*raftstore.RaftError)
+	require.True(t, ok)
+	require.NotNil(t, raftErr.RequestErr.NotLeader)
+	assert.Equal(t, uint64(2), raftErr.RequestErr.NotLeader.RegionId)
+	assert.Equal(t, leader, raftErr.RequestErr.NotLeader.Leader)
+}
+
+func TestSnapReaderNotLeader(t *testing.T) {
+	ris := new(RaftInnerServer)
+	leader := &metapb.Peer{Id: 3, StoreId: 4}
+	cb := message.NewCallback()
+	cb.Done(raftstore.ErrResp(&raftstore.ErrNotLeader{RegionId: 2, Leader: leader}))
+
+	reader, err := ris.snapReader(cb)
+	assert.Nil(t, reader)
+	raftErr, ok := err.(*raftstore.RaftError)
+	require.True(t, ok)
+	require.NotNil(t, raftErr.RequestErr.NotLeader)
+	assert.Equal(t, leader, raftErr.RequestErr.NotLeader.Leader)
+}
@@ -859,7 +859,11 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) (
 		}
 		return nil, errEpochNotMatching
 	}
-	return nil, err
+	if err != nil {
+		return nil, err
+	}
+	// Reject the whole command up front if any key is out of range, so none of it is proposed.
+	return nil, checkKeysInRegion(req, d.region())
 }
 
 func (d *peerMsgHandler) proposeRaftCommand(msg *raft_cmdpb.RaftCmdRequest, cb *message.Callback) {
@@ -0,0 +1,103 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/tikv/config"
+	"github.com/pingcap-incubator/tinykv/kv/tikv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/tikv/worker"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposeKeyNotInRegion(t *testing.T) {
+	engines := newTestEngines(t)
+	defer cleanUpTestEngineData(engines)
+	require.Nil(t, BootstrapStore(engines, 1, 1))
+	region, err := PrepareBootstrap(engines, 1, 1, 1)
+	require.Nil(t, err)
+	region.StartKey = codec.EncodeBytes(nil, []byte("b"))
+	region.EndKey = codec.EncodeBytes(nil, []byte("table0001x"))
+	fsm, err := createPeerFsm(1, config.NewDefaultConfig(), make(chan worker.Task, 10), engines, region)
+	require.Nil(t, err)
+	require.Nil(t, fsm.peer.RaftGroup.Campaign())
+	require.True(t, fsm.peer.IsLeader())
+	d := newRaftMsgHandler(fsm, nil)
+
+	req := &raft_cmdpb.RaftCmdRequest{
+		Header: &raft_cmdpb.RaftRequestHeader{
+			RegionId:    region.Id,
+			Peer:        region.Peers[0],
+			RegionEpoch: region.RegionEpoch,
+		},
+		Requests: []*raft_cmdpb.Request{
+			{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("b"), Value: []byte("v")}},
+		},
+	}
+	// A put exactly at the raw start key is inside the region.
+	_, err = d.preProposeRaftCommand(req)
+	assert.Nil(t, err)
+
+	// A longer key sharing a prefix with the end key, but sorting after it, is outside.
+	req.Requests[0].Put.Key = []byte("table0001y")
+	_, err = d.preProposeRaftCommand(req)
+	_, ok := err.(*ErrKeyNotInRegion)
+	assert.True(t, ok)
+
+	req.Requests = []*raft_cmdpb.Request{
+		{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("b"), Value: []byte("v")}},
+		{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("table0001x"), Value: []byte("v")}},
+	}
+	lastIndex := fsm.peer.RaftGroup.Raft.RaftLog.LastIndex()
+	cb := message.NewCallback()
+	d.proposeRaftCommand(req, cb)
+	cb.Wg.Wait()
+
+	// The whole command is rejected and nothing is appended to the raft log.
+	assert.Equal(t, lastIndex, fsm.peer.RaftGroup.Raft.RaftLog.LastIndex())
+	require.NotNil(t, cb.Resp.Header.Error.KeyNotInRegion)
+	assert.Equal(t, []byte("table0001x"), cb.Resp.Header.Error.KeyNotInRegion.Key)
+	assert.Equal(t, region.Id, cb.Resp.Header.Error.KeyNotInRegion.RegionId)
+
+	// A sub-request with its CmdType set but no body must not panic.
+	req.Requests = []*raft_cmdpb.Request{{CmdType: raft_cmdpb.CmdType_Get}}
+	_, err = d.preProposeRaftCommand(req)
+	_, ok = err.(*ErrKeyNotInRegion)
+	assert.True(t, ok)
+}
+
+func TestProposeSnapNotLeader(t *testing.T) {
+	engines := newTestEngines(t)
+	defer cleanUpTestEngineData(engines)
+	require.Nil(t, BootstrapStore(engines, 1, 1))
+	region, err := PrepareBootstrap(engines, 1, 1, 1)
+	require.Nil(t, err)
+	// A second voter on another store keeps the peer from electing itself.
+	region.Peers = append(region.Peers, &metapb.Peer{Id: 2, StoreId: 2})
+	fsm, err := createPeerFsm(1, config.NewDefaultConfig(), make(chan worker.Task, 10), engines, region)
+	require.Nil(t, err)
+	require.False(t, fsm.peer.IsLeader())
+	d := newRaftMsgHandler(fsm, nil)
+
+	req := &raft_cmdpb.RaftCmdRequest{
+		Header: &raft_cmdpb.RaftRequestHeader{
+			RegionId:    region.Id,
+			Peer:        region.Peers[0],
+			RegionEpoch: region.RegionEpoch,
+		},
+		Requests: []*raft_cmdpb.Request{
+			{CmdType: raft_cmdpb.CmdType_Snap, Snap: &raft_cmdpb.SnapRequest{}},
+		},
+	}
+	cb := message.NewCallback()
+	d.proposeRaftCommand(req, cb)
+	cb.Wg.Wait()
+
+	// This is the response RaftInnerServer.Reader turns into a RaftError.
+	require.NotNil(t, cb.Resp.Header.Error.NotLeader)
+	assert.Equal(t, region.Id, cb.Resp.Header.Error.NotLeader.RegionId)
+	assert.Nil(t, cb.RegionSnap.Txn)
+}
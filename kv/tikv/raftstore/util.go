@@ -234,6 +234,29 @@ func checkPeerID(req *raft_cmdpb.RaftCmdRequest, peerID uint64) error {
 	return errors.Errorf("mismatch peer id %d != %d", peer.Id, peerID)
 }
 
+// checkKeysInRegion checks the raw keys of req against the region range. Region bounds are
+// memcomparable-encoded, so they are decoded before comparing.
+func checkKeysInRegion(req *raft_cmdpb.RaftCmdRequest, region *metapb.Region) error {
+	startKey, endKey := rawRegionKey(region.StartKey), rawRegionKey(region.EndKey)
+	for _, r := range req.Requests {
+		var key []byte
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Get:
+			key = r.GetGet().GetKey()
+		case raft_cmdpb.CmdType_Put:
+			key = r.GetPut().GetKey()
+		case raft_cmdpb.CmdType_Delete:
+			key = r.GetDelete().GetKey()
+		default:
+			continue
+		}
+		if bytes.Compare(key, startKey) < 0 || (len(endKey) != 0 && bytes.Compare(key, endKey) >= 0) {
+			return &ErrKeyNotInRegion{Key: key, Region: region}
+		}
+	}
+	return nil
+}
+
 func CloneMsg(origin, cloned proto.Message) error {
 	data, err := proto.Marshal(origin)
 	if err != nil {
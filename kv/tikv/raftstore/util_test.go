@@ -6,6 +6,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap/tidb/util/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +44,54 @@ func TestCheckKeyInRegion(t *testing.T) {
 	}
 }
 
+func TestCheckKeysInRegion(t *testing.T) {
+	region := &metapb.Region{
+		StartKey: codec.EncodeBytes(nil, []byte("b")),
+		EndKey:   codec.EncodeBytes(nil, []byte("table0001x")),
+	}
+	cases := []struct {
+		Key        []byte
+		IsInRegion bool
+	}{
+		{Key: []byte("a"), IsInRegion: false},
+		{Key: []byte("b"), IsInRegion: true},
+		{Key: []byte("b\x00"), IsInRegion: true},
+		{Key: []byte("table0001"), IsInRegion: true},
+		{Key: []byte("table0001w"), IsInRegion: true},
+		{Key: []byte("table0001x"), IsInRegion: false},
+		{Key: []byte("table0001x\x00"), IsInRegion: false},
+		{Key: []byte("table0001y"), IsInRegion: false},
+	}
+	for _, c := range cases {
+		req := &raft_cmdpb.RaftCmdRequest{
+			Requests: []*raft_cmdpb.Request{
+				{CmdType: raft_cmdpb.CmdType_Snap, Snap: &raft_cmdpb.SnapRequest{}},
+				{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: c.Key}},
+			},
+		}
+		err := checkKeysInRegion(req, region)
+		assert.Equal(t, c.IsInRegion, err == nil, "key %q", c.Key)
+		if !c.IsInRegion {
+			if notInRegion, ok := err.(*ErrKeyNotInRegion); assert.True(t, ok, "key %q", c.Key) {
+				assert.Equal(t, c.Key, notInRegion.Key)
+			}
+		}
+	}
+
+	// One out-of-range key rejects the whole command, whatever its type.
+	req := &raft_cmdpb.RaftCmdRequest{
+		Requests: []*raft_cmdpb.Request{
+			{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("b")}},
+			{CmdType: raft_cmdpb.CmdType_Delete, Delete: &raft_cmdpb.DeleteRequest{Key: []byte("c")}},
+			{CmdType: raft_cmdpb.CmdType_Get, Get: &raft_cmdpb.GetRequest{Key: []byte("a")}},
+		},
+	}
+	assert.NotNil(t, checkKeysInRegion(req, region))
+
+	// Empty bounds mean the region covers the whole key space.
+	assert.Nil(t, checkKeysInRegion(req, &metapb.Region{}))
+}
+
 func TestIsInitialMsg(t *testing.T) {
 	type MsgInfo struct {
 		MessageType  eraftpb.MessageType
@@ -227,7 +227,7 @@ func (snapCtx *snapContext) cleanUpOriginData(regionState *rspb.RegionLocalState
 	if err := snap.CheckAbort(status); err != nil {
 		return err
 	}
-	if err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
+	if _, err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
 		return err
 	}
 	if err := snap.CheckAbort(status); err != nil {
@@ -303,12 +303,12 @@ func (snapCtx *snapContext) handleApply(regionId uint64, status *snap.JobStatus)
 
 // cleanUpRange cleans up the data within the range.
 func (snapCtx *snapContext) cleanUpRange(regionId uint64, startKey, endKey []byte) {
-	if err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
+	if count, err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
 		log.Errorf("failed to delete data in range, [regionId: %d, startKey: %s, endKey: %s, err: %v]", regionId,
 			hex.EncodeToString(startKey), hex.EncodeToString(endKey), err)
 	} else {
-		log.Infof("succeed in deleting data in range. [regionId: %d, startKey: %s, endKey: %s]", regionId,
-			hex.EncodeToString(startKey), hex.EncodeToString(endKey))
+		log.Infof("succeed in deleting data in range. [regionId: %d, startKey: %s, endKey: %s, count: %d]", regionId,
+			hex.EncodeToString(startKey), hex.EncodeToString(endKey), count)
 	}
 }
 
@@ -2,6 +2,7 @@ package tikv
 
 import (
 	"bytes"
+	"sort"
 	"time"
 
 	"github.com/dgryski/go-farm"
@@ -31,7 +32,7 @@ func mutationsToHashVals(mutations []*kvrpcpb.Mutation) []uint64 {
 	for i, mut := range mutations {
 		hashVals[i] = farm.Fingerprint64(mut.Key)
 	}
-	return hashVals
+	return sortHashVals(hashVals)
 }
 
 func keysToHashVals(keys ...[]byte) []uint64 {
@@ -39,7 +40,24 @@ func keysToHashVals(keys ...[]byte) []uint64 {
 	for i, key := range keys {
 		hashVals[i] = farm.Fingerprint64(key)
 	}
-	return hashVals
+	return sortHashVals(hashVals)
+}
+
+// sortHashVals sorts and deduplicates hashVals in place, so the same keys always give the same hash vals
+// in the same order, and latch acquire/release and lock waiter wake-ups see each latch only once.
+// Latch code that acquires latches one at a time rather than all at once must do so in this order.
+func sortHashVals(hashVals []uint64) []uint64 {
+	sort.Slice(hashVals, func(i, j int) bool {
+		return hashVals[i] < hashVals[j]
+	})
+	n := 0
+	for i, val := range hashVals {
+		if i == 0 || val != hashVals[n-1] {
+			hashVals[n] = val
+			n++
+		}
+	}
+	return hashVals[:n]
 }
 
 func safeCopy(b []byte) []byte {
@@ -0,0 +1,24 @@
+package tikv
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashValsCanonicalOrder(t *testing.T) {
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	expected := keysToHashVals(a, b, c)
+	assert.Len(t, expected, 3)
+	for i := 1; i < len(expected); i++ {
+		assert.True(t, expected[i-1] < expected[i])
+	}
+
+	assert.Equal(t, expected, keysToHashVals(c, a, b))
+	assert.Equal(t, expected, keysToHashVals(b, c, a, c, b))
+	assert.Equal(t, expected, mutationsToHashVals([]*kvrpcpb.Mutation{
+		{Key: c}, {Key: b}, {Key: a}, {Key: a},
+	}))
+	assert.Len(t, keysToHashVals(), 0)
+}